@@ -0,0 +1,82 @@
+package acceptance
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	dockerclient "github.com/docker/docker/client"
+	ggcrname "github.com/google/go-containerregistry/pkg/name"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/imgutil/remote"
+	h "github.com/buildpacks/imgutil/testhelpers"
+)
+
+func TestRegistryConfigAcceptance(t *testing.T) {
+	spec.Run(t, "RegistryConfig", testRegistryConfig, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testRegistryConfig(t *testing.T, when spec.G, it spec.S) {
+	var (
+		dockerClient          dockerclient.CommonAPIClient
+		runnableBaseImageName string
+		sourceRegistry        *h.DockerRegistry
+		targetRegistry        *h.DockerRegistry
+	)
+
+	it.Before(func() {
+		dockerClient = h.DockerCli(t)
+
+		daemonInfo, err := dockerClient.Info(context.TODO())
+		h.AssertNil(t, err)
+
+		runnableBaseImageName = "busybox@sha256:915f390a8912e16d4beb8689720a17348f3f6d1a7b659697df850ab625ea29d5"
+		if daemonInfo.OSType == "windows" {
+			runnableBaseImageName = "mcr.microsoft.com/windows/nanoserver@sha256:06281772b6a561411d4b338820d94ab1028fdeb076c85350bbc01e80c4bfa2b4"
+		}
+		h.PullImage(dockerClient, runnableBaseImageName)
+
+		sourceRegistry = h.NewDockerRegistry()
+		sourceRegistry.Start(t)
+
+		targetRegistry = h.NewDockerRegistry()
+		targetRegistry.Start(t)
+	})
+
+	it.After(func() {
+		sourceRegistry.Stop(t)
+		targetRegistry.Stop(t)
+	})
+
+	it("reads a base image from one registry and writes to another using a keychain registered per registry", func() {
+		baseImageName := fmt.Sprintf("%s:%s/imgutil-acceptance-%s", sourceRegistry.Host, sourceRegistry.Port, h.RandString(10))
+		seed, err := remote.NewImage(baseImageName, sourceRegistry.GGCRKeychain(), remote.FromBaseImage(runnableBaseImageName))
+		h.AssertNil(t, err)
+		h.AssertNil(t, seed.Save())
+
+		targetImageName := fmt.Sprintf("%s:%s/imgutil-acceptance-%s", targetRegistry.Host, targetRegistry.Port, h.RandString(10))
+
+		cfg := remote.NewRegistryConfig().
+			WithKeychain(fmt.Sprintf("%s:%s", sourceRegistry.Host, sourceRegistry.Port), sourceRegistry.GGCRKeychain()).
+			WithKeychain(fmt.Sprintf("%s:%s", targetRegistry.Host, targetRegistry.Port), targetRegistry.GGCRKeychain())
+
+		img, err := remote.NewImage(targetImageName, nil, remote.FromBaseImage(baseImageName), remote.WithRegistryConfig(cfg))
+		h.AssertNil(t, err)
+		h.AssertNil(t, img.SetLabel("some-label", "some-value"))
+		h.AssertNil(t, img.Save())
+
+		ref, err := ggcrname.ParseReference(targetImageName, ggcrname.WeakValidation)
+		h.AssertNil(t, err)
+
+		v1img, err := ggcrremote.Image(ref, ggcrremote.WithAuthFromKeychain(targetRegistry.GGCRKeychain()))
+		h.AssertNil(t, err)
+
+		cfgFile, err := v1img.ConfigFile()
+		h.AssertNil(t, err)
+
+		h.AssertEq(t, cfgFile.Config.Labels["some-label"], "some-value")
+	})
+}
@@ -6,6 +6,7 @@ import (
 	"io"
 	"io/ioutil"
 	"math/rand"
+	"os"
 	"testing"
 	"time"
 
@@ -14,11 +15,15 @@ import (
 	dockertypes "github.com/docker/docker/api/types"
 	dockerclient "github.com/docker/docker/client"
 	ggcrname "github.com/google/go-containerregistry/pkg/name"
+	ggcrv1 "github.com/google/go-containerregistry/pkg/v1"
+	ggcrempty "github.com/google/go-containerregistry/pkg/v1/empty"
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
 	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
 	"github.com/sclevine/spec"
 	"github.com/sclevine/spec/report"
 
 	"github.com/buildpacks/imgutil"
+	"github.com/buildpacks/imgutil/layout"
 	"github.com/buildpacks/imgutil/local"
 	"github.com/buildpacks/imgutil/remote"
 	h "github.com/buildpacks/imgutil/testhelpers"
@@ -133,6 +138,138 @@ func testReproducibility(t *testing.T, when spec.G, it spec.S) {
 
 		compare(t, imageName1, imageName2, localTestRegistry.GGCRKeychain())
 	})
+
+	it("remote/remote with a fixed timestamp, built on different days", func() {
+		fixedTime := time.Date(2020, time.January, 1, 0, 0, 0, 0, time.UTC)
+
+		img1, err := remote.NewImage(imageName1, localTestRegistry.GGCRKeychain(), remote.FromBaseImage(runnableBaseImageName), remote.WithCreatedAt(imgutil.TimestampFixed(fixedTime)))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img1)
+
+		// sleep so that img2 is built at a later wall-clock time than img1,
+		// simulating a build kicked off on a later day - TimestampFixed
+		// should make that irrelevant to the resulting digest. Both images
+		// add the same layer files, so their on-disk mtimes do not differ.
+		time.Sleep(1100 * time.Millisecond)
+
+		img2, err := remote.NewImage(imageName2, localTestRegistry.GGCRKeychain(), remote.FromBaseImage(runnableBaseImageName), remote.WithCreatedAt(imgutil.TimestampFixed(fixedTime)))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img2)
+
+		compare(t, imageName1, imageName2, localTestRegistry.GGCRKeychain())
+
+		// Assert the fixed timestamp was actually applied, rather than just
+		// asserting img1 and img2 match each other - both built with the
+		// same option, so a no-op WithCreatedAt would pass the compare above
+		// too. A default (TimestampZero) build would leave Created at the
+		// UNIX epoch, not fixedTime.
+		ref1, err := ggcrname.ParseReference(imageName1, ggcrname.WeakValidation)
+		h.AssertNil(t, err)
+		v1img1, err := ggcrremote.Image(ref1, ggcrremote.WithAuthFromKeychain(localTestRegistry.GGCRKeychain()))
+		h.AssertNil(t, err)
+		cfg1, err := v1img1.ConfigFile()
+		h.AssertNil(t, err)
+		h.AssertEq(t, cfg1.Created.Time.UTC(), fixedTime)
+		for _, hist := range cfg1.History {
+			h.AssertEq(t, hist.Created.Time.UTC(), fixedTime)
+		}
+	})
+
+	it("layout/layout", func() {
+		baseLayoutDir := saveImageLayout(t, runnableBaseImageName)
+		defer os.RemoveAll(baseLayoutDir)
+
+		layoutDir1, err := ioutil.TempDir("", "imgutil-layout-acceptance")
+		h.AssertNil(t, err)
+		defer os.RemoveAll(layoutDir1)
+
+		layoutDir2, err := ioutil.TempDir("", "imgutil-layout-acceptance")
+		h.AssertNil(t, err)
+		defer os.RemoveAll(layoutDir2)
+
+		img1, err := layout.NewImage(layoutDir1, imageName1, layout.FromBaseImage(baseLayoutDir))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img1)
+
+		img2, err := layout.NewImage(layoutDir2, imageName2, layout.FromBaseImage(baseLayoutDir))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img2)
+
+		compareV1Images(t, img1.UnderlyingImage(), img2.UnderlyingImage())
+	})
+
+	it("layout/remote", func() {
+		baseLayoutDir := saveImageLayout(t, runnableBaseImageName)
+		defer os.RemoveAll(baseLayoutDir)
+
+		layoutDir1, err := ioutil.TempDir("", "imgutil-layout-acceptance")
+		h.AssertNil(t, err)
+		defer os.RemoveAll(layoutDir1)
+
+		img1, err := layout.NewImage(layoutDir1, imageName1, layout.FromBaseImage(baseLayoutDir))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img1)
+		pushV1Image(t, imageName1, img1.UnderlyingImage(), localTestRegistry.GGCRKeychain())
+
+		img2, err := remote.NewImage(imageName2, localTestRegistry.GGCRKeychain(), remote.FromBaseImage(runnableBaseImageName))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img2)
+
+		compare(t, imageName1, imageName2, localTestRegistry.GGCRKeychain())
+	})
+
+	it("tarball/remote", func() {
+		tarballPath := saveImageTarball(t, dockerClient, runnableBaseImageName)
+		defer os.Remove(tarballPath)
+
+		img1, err := remote.NewImage(imageName1, localTestRegistry.GGCRKeychain(), remote.FromBaseImageTarball(tarballPath))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img1)
+
+		img2, err := remote.NewImage(imageName2, localTestRegistry.GGCRKeychain(), remote.FromBaseImage(runnableBaseImageName))
+		h.AssertNil(t, err)
+		mutateAndSave(t, img2)
+
+		compare(t, imageName1, imageName2, localTestRegistry.GGCRKeychain())
+	})
+}
+
+// saveImageTarball `docker save`s ref to a temp file and returns its path,
+// so a base image can be seeded from a vendored artifact instead of a
+// registry or the daemon.
+func saveImageTarball(t *testing.T, dockerClient dockerclient.CommonAPIClient, ref string) string {
+	rc, err := dockerClient.ImageSave(context.TODO(), []string{ref})
+	h.AssertNil(t, err)
+	defer rc.Close()
+
+	tarFile, err := ioutil.TempFile("", "base-image-tarball")
+	h.AssertNil(t, err)
+	defer tarFile.Close()
+
+	_, err = io.Copy(tarFile, rc)
+	h.AssertNil(t, err)
+
+	return tarFile.Name()
+}
+
+// saveImageLayout pulls ref from its registry into a new single-manifest OCI
+// Image Layout directory and returns its path, so layout.FromBaseImage can
+// seed from the same base image that remote.FromBaseImage pulls.
+func saveImageLayout(t *testing.T, ref string) string {
+	parsed, err := ggcrname.ParseReference(ref, ggcrname.WeakValidation)
+	h.AssertNil(t, err)
+
+	img, err := ggcrremote.Image(parsed)
+	h.AssertNil(t, err)
+
+	dir, err := ioutil.TempDir("", "imgutil-base-layout")
+	h.AssertNil(t, err)
+
+	p, err := ggcrlayout.Write(dir, ggcrempty.Index)
+	h.AssertNil(t, err)
+	h.AssertNil(t, p.AppendImage(img))
+
+	return dir
 }
 
 func randomLayer(t *testing.T, osType string) string {
@@ -161,13 +298,39 @@ func compare(t *testing.T, img1, img2 string, keychain ggcrauthn.Keychain) {
 	v1img2, err := ggcrremote.Image(ref2, ggcrremote.WithAuthFromKeychain(keychain))
 	h.AssertNil(t, err)
 
-	cfg1, err := v1img1.ConfigFile()
+	compareV1Images(t, v1img1, v1img2)
+
+	h.AssertEq(t, ref1.Identifier(), ref2.Identifier())
+}
+
+// compareV1Images asserts that two go-containerregistry images have
+// identical config files, which - combined with identical digests - proves
+// byte-for-byte reproducibility regardless of which imgutil.Image backend
+// produced them.
+func compareV1Images(t *testing.T, img1, img2 ggcrv1.Image) {
+	cfg1, err := img1.ConfigFile()
 	h.AssertNil(t, err)
 
-	cfg2, err := v1img2.ConfigFile()
+	cfg2, err := img2.ConfigFile()
 	h.AssertNil(t, err)
 
 	h.AssertEq(t, cfg1, cfg2)
 
-	h.AssertEq(t, ref1.Identifier(), ref2.Identifier())
+	digest1, err := img1.Digest()
+	h.AssertNil(t, err)
+
+	digest2, err := img2.Digest()
+	h.AssertNil(t, err)
+
+	h.AssertEq(t, digest1, digest2)
+}
+
+// pushV1Image pushes a go-containerregistry image built outside of the
+// remote package (e.g. by the layout package) to name, so it can be
+// compared against images produced directly by remote.Image.
+func pushV1Image(t *testing.T, name string, img ggcrv1.Image, keychain ggcrauthn.Keychain) {
+	ref, err := ggcrname.ParseReference(name, ggcrname.WeakValidation)
+	h.AssertNil(t, err)
+
+	h.AssertNil(t, ggcrremote.Write(ref, img, ggcrremote.WithAuthFromKeychain(keychain)))
 }
@@ -0,0 +1,63 @@
+package acceptance
+
+import (
+	"context"
+	"testing"
+
+	dockerclient "github.com/docker/docker/client"
+	"github.com/sclevine/spec"
+	"github.com/sclevine/spec/report"
+
+	"github.com/buildpacks/imgutil/remote"
+	h "github.com/buildpacks/imgutil/testhelpers"
+)
+
+func TestSigningAcceptance(t *testing.T) {
+	localTestRegistry = h.NewDockerRegistry()
+	localTestRegistry.Start(t)
+	defer localTestRegistry.Stop(t)
+
+	spec.Run(t, "Signing", testSigning, spec.Sequential(), spec.Report(report.Terminal{}))
+}
+
+func testSigning(t *testing.T, when spec.G, it spec.S) {
+	var (
+		dockerClient          dockerclient.CommonAPIClient
+		runnableBaseImageName string
+	)
+
+	it.Before(func() {
+		dockerClient = h.DockerCli(t)
+
+		daemonInfo, err := dockerClient.Info(context.TODO())
+		h.AssertNil(t, err)
+
+		runnableBaseImageName = "busybox@sha256:915f390a8912e16d4beb8689720a17348f3f6d1a7b659697df850ab625ea29d5"
+		if daemonInfo.OSType == "windows" {
+			runnableBaseImageName = "mcr.microsoft.com/windows/nanoserver@sha256:06281772b6a561411d4b338820d94ab1028fdeb076c85350bbc01e80c4bfa2b4"
+		}
+		h.PullImage(dockerClient, runnableBaseImageName)
+	})
+
+	it("lets a second remote.NewImage enumerate a signature attached after Save", func() {
+		imageName := newTestImageName()
+
+		img, err := remote.NewImage(imageName, localTestRegistry.GGCRKeychain(), remote.FromBaseImage(runnableBaseImageName))
+		h.AssertNil(t, err)
+		h.AssertNil(t, img.SetLabel("some-label", "some-value"))
+		h.AssertNil(t, img.Save())
+
+		payload := []byte(`{"critical":{"identity":{"docker-reference":"` + imageName + `"}}}`)
+		signature := []byte("fake-signature-bytes")
+		h.AssertNil(t, img.AttachSignature(payload, signature, nil, nil))
+
+		reread, err := remote.NewImage(imageName, localTestRegistry.GGCRKeychain(), remote.FromBaseImage(imageName))
+		h.AssertNil(t, err)
+
+		sigs, err := reread.Signatures()
+		h.AssertNil(t, err)
+		h.AssertEq(t, len(sigs), 1)
+		h.AssertEq(t, sigs[0].Payload, payload)
+		h.AssertEq(t, sigs[0].Sig, signature)
+	})
+}
@@ -0,0 +1,60 @@
+package imgutil
+
+import "time"
+
+// createdAtMode selects how a CreatedAt value resolves to a concrete time.
+type createdAtMode int
+
+const (
+	modeZero createdAtMode = iota
+	modeSource
+	modeFixed
+)
+
+// CreatedAt governs the `created` field written to an image's config file,
+// the `created_by` entries in its history, and the mtime/atime/ctime of
+// every tar entry written for a layer added via Image.AddLayer. Image
+// implementations default to TimestampZero, which has been imgutil's
+// de-facto behavior since its initial release.
+type CreatedAt struct {
+	mode  createdAtMode
+	fixed time.Time
+}
+
+// TimestampZero resolves every timestamp to the UNIX epoch (1970-01-01
+// 00:00:00 UTC), regardless of the source content's actual mtime or the
+// base image's Created time.
+var TimestampZero = CreatedAt{mode: modeZero}
+
+// TimestampSource resolves the image's `created` field to the base image's
+// own Created time, and each layer's tar entry timestamps to the mtime of
+// the source file or directory being added.
+var TimestampSource = CreatedAt{mode: modeSource}
+
+// TimestampFixed resolves every timestamp to t, regardless of source mtimes
+// or the base image's Created time - the SOURCE_DATE_EPOCH convention used
+// by other reproducible-build tooling.
+func TimestampFixed(t time.Time) CreatedAt {
+	return CreatedAt{mode: modeFixed, fixed: t}
+}
+
+// Resolve returns the concrete time this CreatedAt policy implies, given the
+// time that would otherwise have been used (a source file's mtime, or a
+// base image's Created field).
+func (c CreatedAt) Resolve(sourceTime time.Time) time.Time {
+	switch c.mode {
+	case modeFixed:
+		return c.fixed
+	case modeSource:
+		return sourceTime
+	default:
+		return time.Unix(0, 0).UTC()
+	}
+}
+
+// UsesSourceTime reports whether this CreatedAt policy is TimestampSource,
+// meaning callers must look up the relevant source mtime (or base image
+// Created time) themselves before calling Resolve.
+func (c CreatedAt) UsesSourceTime() bool {
+	return c.mode == modeSource
+}
@@ -0,0 +1,32 @@
+package imgutil
+
+import (
+	"testing"
+	"time"
+)
+
+func TestCreatedAtResolve(t *testing.T) {
+	fixed := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+	source := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+
+	for _, tc := range []struct {
+		name       string
+		createdAt  CreatedAt
+		sourceTime time.Time
+		want       time.Time
+		usesSource bool
+	}{
+		{name: "zero", createdAt: TimestampZero, sourceTime: source, want: time.Unix(0, 0).UTC()},
+		{name: "fixed", createdAt: TimestampFixed(fixed), sourceTime: source, want: fixed},
+		{name: "source", createdAt: TimestampSource, sourceTime: source, want: source, usesSource: true},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			if got := tc.createdAt.UsesSourceTime(); got != tc.usesSource {
+				t.Errorf("UsesSourceTime() = %v, want %v", got, tc.usesSource)
+			}
+			if got := tc.createdAt.Resolve(tc.sourceTime); !got.Equal(tc.want) {
+				t.Errorf("Resolve(%v) = %v, want %v", tc.sourceTime, got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,49 @@
+package imgutil
+
+import (
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/pkg/errors"
+)
+
+// NormalizeImageTimestamps resolves img's CreatedAt policy and applies it to
+// img's config file, returning the resulting image. local.Image and
+// remote.Image call this from Save, after AddLayer has already normalized
+// each newly-added layer's own tar entry timestamps.
+//
+// TimestampSource is handled differently from TimestampZero and
+// TimestampFixed: it only rewrites the config's `created` field and each
+// history entry's timestamp to the base image's own Created time, leaving
+// every layer's tar entry timestamps exactly as AddLayer left them (each
+// layer normalized to its own source file's mtime). TimestampZero and
+// TimestampFixed instead rewrite every layer's tar entry timestamps too,
+// via mutate.Time, since a single imgutil-wide timestamp is the whole point
+// of those policies.
+func NormalizeImageTimestamps(img v1.Image, createdAt CreatedAt) (v1.Image, error) {
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading config file")
+	}
+
+	if createdAt.UsesSourceTime() {
+		resolved := createdAt.Resolve(cfg.Created.Time)
+		cfg.Created = v1.Time{Time: resolved}
+		for idx := range cfg.History {
+			cfg.History[idx].Created = v1.Time{Time: resolved}
+		}
+		out, err := mutate.ConfigFile(img, cfg)
+		if err != nil {
+			return nil, errors.Wrap(err, "normalizing image timestamps")
+		}
+		return out, nil
+	}
+
+	resolved := createdAt.Resolve(time.Time{})
+	out, err := mutate.Time(img, resolved)
+	if err != nil {
+		return nil, errors.Wrap(err, "normalizing image timestamps")
+	}
+	return out, nil
+}
@@ -0,0 +1,105 @@
+package imgutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io"
+	"testing"
+	"time"
+
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+func tarGzLayer(t *testing.T, content string) []byte {
+	t.Helper()
+	var buf bytes.Buffer
+	gw := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gw)
+	if err := tw.WriteHeader(&tar.Header{Name: "file.txt", Typeflag: tar.TypeReg, Size: int64(len(content))}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte(content)); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	if err := gw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	return buf.Bytes()
+}
+
+func TestNormalizeImageTimestampsFixed(t *testing.T) {
+	fixed := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	img, err := NormalizeImageTimestamps(empty.Image, TimestampFixed(fixed))
+	if err != nil {
+		t.Fatal(err)
+	}
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Created.Time.Equal(fixed) {
+		t.Errorf("Created = %v, want %v", cfg.Created.Time, fixed)
+	}
+}
+
+func TestNormalizeImageTimestampsSourceLeavesLayersUntouched(t *testing.T) {
+	configCreated := time.Date(2021, 6, 7, 8, 9, 10, 0, time.UTC)
+	tgz := tarGzLayer(t, "layer content")
+	layer, err := tarball.LayerFromOpener(func() (io.ReadCloser, error) {
+		return io.NopCloser(bytes.NewReader(tgz)), nil
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, err := mutate.AppendLayers(empty.Image, layer)
+	if err != nil {
+		t.Fatal(err)
+	}
+	base, err = mutate.Time(base, configCreated)
+	if err != nil {
+		t.Fatal(err)
+	}
+	baseLayers, err := base.Layers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	wantDigest, err := baseLayers[0].Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	img, err := NormalizeImageTimestamps(base, TimestampSource)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	cfg, err := img.ConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !cfg.Created.Time.Equal(configCreated) {
+		t.Errorf("Created = %v, want %v", cfg.Created.Time, configCreated)
+	}
+
+	layers, err := img.Layers()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(layers) != 1 {
+		t.Fatalf("expected 1 layer, got %d", len(layers))
+	}
+	gotDigest, err := layers[0].Digest()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if gotDigest != wantDigest {
+		t.Errorf("layer digest changed: got %v, want %v (TimestampSource must not rewrite layer content)", gotDigest, wantDigest)
+	}
+}
@@ -0,0 +1,23 @@
+// Package imgutil provides a consistent interface for modifying images,
+// whether they're sourced from a registry, an OCI Image Layout, or the
+// Docker daemon.
+package imgutil
+
+import v1 "github.com/google/go-containerregistry/pkg/v1"
+
+// Image is the common interface implemented by every imgutil backend: the
+// local package (backed by the Docker daemon), the remote package (backed
+// directly by a registry), and the layout package (backed by an on-disk OCI
+// Image Layout).
+type Image interface {
+	AddLayer(path string) error
+	SetLabel(key, val string) error
+	SetEnv(key, val string) error
+	SetEntrypoint(ep ...string) error
+	SetCmd(cmd ...string) error
+	SetWorkingDir(dir string) error
+	SetCreatedAt(createdAt CreatedAt) error
+	Save(additionalNames ...string) error
+	ConfigFile() (*v1.ConfigFile, error)
+	Digest() (string, error)
+}
@@ -0,0 +1,13 @@
+package layout
+
+import "github.com/buildpacks/imgutil"
+
+// WithCreatedAt sets the CreatedAt policy governing the image's `created`
+// field, its layer history `created_by` entries, and the tar entry
+// timestamps of any layer added via AddLayer. It defaults to
+// imgutil.TimestampZero.
+func WithCreatedAt(createdAt imgutil.CreatedAt) ImageOption {
+	return func(o *imageOptions) {
+		o.createdAt = createdAt
+	}
+}
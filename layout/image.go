@@ -0,0 +1,241 @@
+package layout
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"time"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/match"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/imgutil"
+)
+
+// Image implements imgutil.Image on top of an OCI Image Layout directory.
+type Image struct {
+	path       string
+	tag        string
+	image      v1.Image
+	mediaTypes MediaTypes
+	createdAt  imgutil.CreatedAt
+}
+
+// NewImage returns an Image that will be written to an OCI Image Layout at
+// path when Save is called. path is created if it does not already exist.
+// The tag argument identifies this image within the layout's index.json via
+// the org.opencontainers.image.ref.name annotation.
+func NewImage(path string, tag string, ops ...ImageOption) (*Image, error) {
+	var opts imageOptions
+	for _, op := range ops {
+		op(&opts)
+	}
+
+	base := empty.Image
+	if opts.baseImagePath != "" {
+		img, err := imageFromLayoutPath(opts.baseImagePath)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading base image from %s", opts.baseImagePath)
+		}
+		base = img
+	}
+
+	return &Image{
+		path:       path,
+		tag:        tag,
+		image:      base,
+		mediaTypes: opts.mediaTypes,
+		createdAt:  opts.createdAt,
+	}, nil
+}
+
+func imageFromLayoutPath(path string) (v1.Image, error) {
+	ii, err := ggcrlayout.ImageIndexFromPath(path)
+	if err != nil {
+		return nil, err
+	}
+	idxManifest, err := ii.IndexManifest()
+	if err != nil {
+		return nil, err
+	}
+	if len(idxManifest.Manifests) != 1 {
+		return nil, fmt.Errorf("expected exactly one manifest in %s, found %d", path, len(idxManifest.Manifests))
+	}
+	return ii.Image(idxManifest.Manifests[0].Digest)
+}
+
+// AddLayer adds the uncompressed tar at path as a new layer on top of the
+// image, normalizing its entry timestamps according to this image's
+// CreatedAt policy.
+func (i *Image) AddLayer(path string) error {
+	sourceTime := time.Time{}
+	if i.createdAt.UsesSourceTime() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "statting %s", path)
+		}
+		sourceTime = info.ModTime()
+	}
+	resolved := i.createdAt.Resolve(sourceTime)
+
+	src, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", path)
+	}
+	defer src.Close()
+
+	normalized, err := ioutil.TempFile("", "imgutil-layer-normalized")
+	if err != nil {
+		return errors.Wrap(err, "creating temp file for normalized layer")
+	}
+	defer os.Remove(normalized.Name())
+	defer normalized.Close()
+
+	if err := imgutil.NormalizeLayerTimestamps(normalized, src, resolved); err != nil {
+		return errors.Wrapf(err, "normalizing layer timestamps for %s", path)
+	}
+
+	layer, err := tarball.LayerFromFile(normalized.Name())
+	if err != nil {
+		return errors.Wrapf(err, "creating layer from %s", normalized.Name())
+	}
+	img, err := mutate.AppendLayers(i.image, layer)
+	if err != nil {
+		return errors.Wrap(err, "appending layer")
+	}
+	i.image = img
+	return nil
+}
+
+// SetLabel sets a label in the image config.
+func (i *Image) SetLabel(key, val string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		if cfg.Labels == nil {
+			cfg.Labels = map[string]string{}
+		}
+		cfg.Labels[key] = val
+	})
+}
+
+// SetEnv sets an environment variable in the image config, in KEY=VAL form.
+func (i *Image) SetEnv(key, val string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		entry := fmt.Sprintf("%s=%s", key, val)
+		for idx, e := range cfg.Env {
+			if hasEnvKey(e, key) {
+				cfg.Env[idx] = entry
+				return
+			}
+		}
+		cfg.Env = append(cfg.Env, entry)
+	})
+}
+
+// SetEntrypoint sets the image's entrypoint.
+func (i *Image) SetEntrypoint(ep ...string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		cfg.Entrypoint = ep
+	})
+}
+
+// SetCmd sets the image's default command.
+func (i *Image) SetCmd(cmd ...string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		cfg.Cmd = cmd
+	})
+}
+
+// SetWorkingDir sets the image's working directory.
+func (i *Image) SetWorkingDir(dir string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		cfg.WorkingDir = dir
+	})
+}
+
+func (i *Image) mutateConfig(f func(*v1.Config)) error {
+	cfgFile, err := i.image.ConfigFile()
+	if err != nil {
+		return errors.Wrap(err, "reading config file")
+	}
+	cfg := cfgFile.Config
+	f(&cfg)
+	img, err := mutate.Config(i.image, cfg)
+	if err != nil {
+		return errors.Wrap(err, "mutating config")
+	}
+	i.image = img
+	return nil
+}
+
+// ConfigFile returns the image's config file.
+func (i *Image) ConfigFile() (*v1.ConfigFile, error) {
+	return i.image.ConfigFile()
+}
+
+// Digest returns the hex-encoded sha256 digest of the image's manifest.
+func (i *Image) Digest() (string, error) {
+	h, err := i.image.Digest()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
+
+// UnderlyingImage exposes the go-containerregistry v1.Image backing this
+// Image, for callers (such as the remote package) that need to push it
+// without round-tripping through disk again.
+func (i *Image) UnderlyingImage() v1.Image {
+	return i.image
+}
+
+// Save writes the image to its OCI Image Layout path, writing any new blobs
+// and updating (or appending) the index.json descriptor tagged with this
+// image's name. Save is safe to call more than once: unchanged blobs are
+// left in place rather than rewritten.
+func (i *Image) Save(additionalNames ...string) error {
+	img, err := imgutil.NormalizeImageTimestamps(i.image, i.createdAt)
+	if err != nil {
+		return err
+	}
+	i.image = img
+
+	if i.mediaTypes == DockerTypes {
+		i.image = mutate.MediaType(i.image, types.DockerManifestSchema2)
+	}
+
+	p, err := initOrOpenPath(i.path)
+	if err != nil {
+		return errors.Wrapf(err, "initializing layout at %s", i.path)
+	}
+
+	names := append([]string{i.tag}, additionalNames...)
+	for _, name := range names {
+		if err := p.RemoveDescriptors(match.Name(name)); err != nil {
+			return errors.Wrapf(err, "removing existing descriptor for %s", name)
+		}
+		if err := p.AppendImage(i.image, ggcrlayout.WithAnnotations(map[string]string{
+			refAnnotation: name,
+		})); err != nil {
+			return errors.Wrapf(err, "appending image descriptor for %s", name)
+		}
+	}
+	return nil
+}
+
+func initOrOpenPath(path string) (ggcrlayout.Path, error) {
+	if _, err := os.Stat(path); os.IsNotExist(err) {
+		return ggcrlayout.Write(path, empty.Index)
+	}
+	return ggcrlayout.FromPath(path)
+}
+
+func hasEnvKey(entry, key string) bool {
+	prefix := key + "="
+	return len(entry) >= len(prefix) && entry[:len(prefix)] == prefix
+}
@@ -0,0 +1,27 @@
+package layout
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/v1/types"
+)
+
+func TestSaveDockerTypesManifestOnly(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "layout")
+	img, err := NewImage(path, "my-image", WithMediaTypes(DockerTypes))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := img.Save(); err != nil {
+		t.Fatal(err)
+	}
+
+	mt, err := img.image.MediaType()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if mt != types.DockerManifestSchema2 {
+		t.Errorf("manifest media type = %v, want %v", mt, types.DockerManifestSchema2)
+	}
+}
@@ -0,0 +1,10 @@
+// Package layout implements the imgutil.Image interface on top of an OCI
+// Image Layout (https://github.com/opencontainers/image-spec/blob/main/image-layout.md)
+// stored on disk. It lets callers build and inspect images without a daemon
+// or registry, which is useful for air-gapped builds and for composing
+// images that will later be pushed with the remote package.
+package layout
+
+// refAnnotation is the OCI annotation used to tag a manifest descriptor
+// in index.json with a human-readable reference name.
+const refAnnotation = "org.opencontainers.image.ref.name"
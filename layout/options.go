@@ -0,0 +1,46 @@
+package layout
+
+import "github.com/buildpacks/imgutil"
+
+// MediaTypes selects whether a layout.Image writes an OCI or Docker media
+// type for its manifest.
+type MediaTypes int
+
+const (
+	// OCITypes is the default. Save leaves the manifest media type as the
+	// image already has it; it does not convert it to an
+	// application/vnd.oci.image.* media type.
+	OCITypes MediaTypes = iota
+	// DockerTypes makes Save write the manifest with the
+	// application/vnd.docker.distribution.manifest.v2+json media type, for
+	// consumers that don't understand OCI media types.
+	DockerTypes
+)
+
+type imageOptions struct {
+	baseImagePath string
+	mediaTypes    MediaTypes
+	createdAt     imgutil.CreatedAt
+}
+
+// ImageOption is a functional option for NewImage.
+type ImageOption func(*imageOptions)
+
+// FromBaseImage seeds the new image from the OCI Image Layout at path. The
+// layout must contain exactly one manifest, and path must already exist and
+// contain a valid index.json - NewImage returns an error otherwise, it does
+// not fall back to an empty image.
+func FromBaseImage(path string) ImageOption {
+	return func(o *imageOptions) {
+		o.baseImagePath = path
+	}
+}
+
+// WithMediaTypes selects the media type Save uses for the image's manifest.
+// It does not affect the config or layer media types, which keep whatever
+// media type they already had.
+func WithMediaTypes(mediaTypes MediaTypes) ImageOption {
+	return func(o *imageOptions) {
+		o.mediaTypes = mediaTypes
+	}
+}
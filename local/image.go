@@ -0,0 +1,212 @@
+package local
+
+import (
+	"io/ioutil"
+	"os"
+	"time"
+
+	"github.com/docker/docker/client"
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/daemon"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/imgutil"
+)
+
+// Image implements imgutil.Image against the Docker daemon.
+type Image struct {
+	repoName     string
+	dockerClient client.CommonAPIClient
+	image        v1.Image
+	createdAt    imgutil.CreatedAt
+}
+
+// NewImage returns an Image that will be loaded into the Docker daemon as
+// repoName when Save is called.
+func NewImage(repoName string, dockerClient client.CommonAPIClient, ops ...ImageOption) (*Image, error) {
+	var opts imageOptions
+	for _, op := range ops {
+		if err := op(&opts); err != nil {
+			return nil, err
+		}
+	}
+
+	i := &Image{
+		repoName:     repoName,
+		dockerClient: dockerClient,
+		image:        empty.Image,
+		createdAt:    opts.createdAt,
+	}
+
+	switch {
+	case opts.baseImage != nil:
+		// seeded directly via FromBaseImageTarball/FromBaseImageLayout - no
+		// daemon round-trip needed.
+		i.image = opts.baseImage
+	case opts.baseImageRepoName != "":
+		base, err := i.readImage(opts.baseImageRepoName)
+		if err != nil {
+			return nil, errors.Wrapf(err, "reading base image %s", opts.baseImageRepoName)
+		}
+		i.image = base
+	}
+
+	return i, nil
+}
+
+func (i *Image) readImage(ref string) (v1.Image, error) {
+	parsed, err := name.ParseReference(ref, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing reference %s", ref)
+	}
+	return daemon.Image(parsed, daemon.WithClient(i.dockerClient))
+}
+
+// AddLayer adds the uncompressed tar at path as a new layer, normalizing its
+// entry timestamps according to this image's CreatedAt policy before it is
+// ever loaded into the daemon.
+func (i *Image) AddLayer(path string) error {
+	sourceTime := time.Time{}
+	if i.createdAt.UsesSourceTime() {
+		info, err := os.Stat(path)
+		if err != nil {
+			return errors.Wrapf(err, "statting %s", path)
+		}
+		sourceTime = info.ModTime()
+	}
+	resolved := i.createdAt.Resolve(sourceTime)
+
+	src, err := os.Open(path)
+	if err != nil {
+		return errors.Wrapf(err, "opening %s", path)
+	}
+	defer src.Close()
+
+	normalized, err := ioutil.TempFile("", "imgutil-layer-normalized")
+	if err != nil {
+		return errors.Wrap(err, "creating temp file for normalized layer")
+	}
+	defer os.Remove(normalized.Name())
+	defer normalized.Close()
+
+	if err := imgutil.NormalizeLayerTimestamps(normalized, src, resolved); err != nil {
+		return errors.Wrapf(err, "normalizing layer timestamps for %s", path)
+	}
+
+	layer, err := tarball.LayerFromFile(normalized.Name())
+	if err != nil {
+		return errors.Wrapf(err, "creating layer from %s", normalized.Name())
+	}
+
+	img, err := mutate.AppendLayers(i.image, layer)
+	if err != nil {
+		return errors.Wrap(err, "appending layer")
+	}
+	i.image = img
+	return nil
+}
+
+// SetLabel sets a label in the image config.
+func (i *Image) SetLabel(key, val string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		if cfg.Labels == nil {
+			cfg.Labels = map[string]string{}
+		}
+		cfg.Labels[key] = val
+	})
+}
+
+// SetEnv sets an environment variable in the image config, in KEY=VAL form.
+func (i *Image) SetEnv(key, val string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		entry := key + "=" + val
+		for idx, e := range cfg.Env {
+			if hasEnvKey(e, key) {
+				cfg.Env[idx] = entry
+				return
+			}
+		}
+		cfg.Env = append(cfg.Env, entry)
+	})
+}
+
+// SetEntrypoint sets the image's entrypoint.
+func (i *Image) SetEntrypoint(ep ...string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		cfg.Entrypoint = ep
+	})
+}
+
+// SetCmd sets the image's default command.
+func (i *Image) SetCmd(cmd ...string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		cfg.Cmd = cmd
+	})
+}
+
+// SetWorkingDir sets the image's working directory.
+func (i *Image) SetWorkingDir(dir string) error {
+	return i.mutateConfig(func(cfg *v1.Config) {
+		cfg.WorkingDir = dir
+	})
+}
+
+func (i *Image) mutateConfig(f func(*v1.Config)) error {
+	cfgFile, err := i.image.ConfigFile()
+	if err != nil {
+		return errors.Wrap(err, "reading config file")
+	}
+	cfg := cfgFile.Config
+	f(&cfg)
+	img, err := mutate.Config(i.image, cfg)
+	if err != nil {
+		return errors.Wrap(err, "mutating config")
+	}
+	i.image = img
+	return nil
+}
+
+// ConfigFile returns the image's config file.
+func (i *Image) ConfigFile() (*v1.ConfigFile, error) {
+	return i.image.ConfigFile()
+}
+
+// Digest returns the hex-encoded sha256 digest of the image's manifest.
+func (i *Image) Digest() (string, error) {
+	h, err := i.image.Digest()
+	if err != nil {
+		return "", err
+	}
+	return h.String(), nil
+}
+
+// Save normalizes the image's `created` field and each history entry's
+// `created` timestamp according to this image's CreatedAt policy, then
+// loads the image into the daemon as repoName and any additionalNames.
+func (i *Image) Save(additionalNames ...string) error {
+	img, err := imgutil.NormalizeImageTimestamps(i.image, i.createdAt)
+	if err != nil {
+		return err
+	}
+	i.image = img
+
+	for _, n := range append([]string{i.repoName}, additionalNames...) {
+		tag, err := name.NewTag(n, name.WeakValidation)
+		if err != nil {
+			return errors.Wrapf(err, "parsing tag %s", n)
+		}
+		if _, err := daemon.Write(tag, i.image, daemon.WithClient(i.dockerClient)); err != nil {
+			return errors.Wrapf(err, "loading image into daemon as %s", n)
+		}
+	}
+	return nil
+}
+
+func hasEnvKey(entry, key string) bool {
+	prefix := key + "="
+	return len(entry) >= len(prefix) && entry[:len(prefix)] == prefix
+}
@@ -0,0 +1,24 @@
+package local
+
+import (
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/buildpacks/imgutil"
+)
+
+type imageOptions struct {
+	baseImageRepoName string
+	baseImage         v1.Image
+	createdAt         imgutil.CreatedAt
+}
+
+// ImageOption is a functional option for NewImage.
+type ImageOption func(*imageOptions) error
+
+// FromBaseImage seeds the new image by reading ref from the Docker daemon.
+func FromBaseImage(ref string) ImageOption {
+	return func(o *imageOptions) error {
+		o.baseImageRepoName = ref
+		return nil
+	}
+}
@@ -0,0 +1,97 @@
+package local
+
+import (
+	"path/filepath"
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+)
+
+func labeledBaseImage(t *testing.T) v1.Image {
+	t.Helper()
+	img, err := mutate.Config(empty.Image, v1.Config{Labels: map[string]string{"base": "true"}})
+	if err != nil {
+		t.Fatal(err)
+	}
+	return img
+}
+
+func TestFromBaseImageTarball(t *testing.T) {
+	base := labeledBaseImage(t)
+
+	tag, err := name.NewTag("base:latest", name.WeakValidation)
+	if err != nil {
+		t.Fatal(err)
+	}
+	path := filepath.Join(t.TempDir(), "base.tar")
+	if err := tarball.WriteToFile(path, tag, base); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts imageOptions
+	if err := FromBaseImageTarball(path)(&opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.baseImage == nil {
+		t.Fatal("baseImage was not set")
+	}
+	cfg, err := opts.baseImage.ConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Config.Labels["base"] != "true" {
+		t.Errorf("baseImage config labels = %v, want base=true", cfg.Config.Labels)
+	}
+}
+
+func TestFromBaseImageLayout(t *testing.T) {
+	base := labeledBaseImage(t)
+
+	path := t.TempDir()
+	p, err := ggcrlayout.Write(path, empty.Index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AppendImage(base); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts imageOptions
+	if err := FromBaseImageLayout(path)(&opts); err != nil {
+		t.Fatal(err)
+	}
+	if opts.baseImage == nil {
+		t.Fatal("baseImage was not set")
+	}
+	cfg, err := opts.baseImage.ConfigFile()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if cfg.Config.Labels["base"] != "true" {
+		t.Errorf("baseImage config labels = %v, want base=true", cfg.Config.Labels)
+	}
+}
+
+func TestFromBaseImageLayoutRejectsMultiManifest(t *testing.T) {
+	path := t.TempDir()
+	p, err := ggcrlayout.Write(path, empty.Index)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AppendImage(labeledBaseImage(t)); err != nil {
+		t.Fatal(err)
+	}
+	if err := p.AppendImage(empty.Image); err != nil {
+		t.Fatal(err)
+	}
+
+	var opts imageOptions
+	if err := FromBaseImageLayout(path)(&opts); err == nil {
+		t.Fatal("expected an error for a layout with more than one manifest")
+	}
+}
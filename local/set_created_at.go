@@ -0,0 +1,11 @@
+package local
+
+import "github.com/buildpacks/imgutil"
+
+// SetCreatedAt overrides the CreatedAt policy set at construction time (via
+// WithCreatedAt), for callers that only know the desired policy after
+// inspecting the base image.
+func (i *Image) SetCreatedAt(createdAt imgutil.CreatedAt) error {
+	i.createdAt = createdAt
+	return nil
+}
@@ -0,0 +1,31 @@
+package local
+
+import (
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/imgutil"
+)
+
+// errSigningRequiresPush is returned by every signing method on Image: the
+// Docker daemon has no concept of a loose OCI artifact, so there is nowhere
+// to store a signature or attestation until the image has a registry
+// reference of its own.
+var errSigningRequiresPush = errors.New("cannot attach a signature to a local image: push the image first and sign the resulting remote.Image")
+
+// AttachSignature always fails for a local image; push the image and sign
+// it as a remote.Image instead.
+func (i *Image) AttachSignature(payload []byte, signature []byte, cert []byte, chain []byte) error {
+	return errSigningRequiresPush
+}
+
+// AttachAttestation always fails for a local image; push the image and sign
+// it as a remote.Image instead.
+func (i *Image) AttachAttestation(predicateType string, predicate []byte) error {
+	return errSigningRequiresPush
+}
+
+// Signatures always fails for a local image; push the image and read
+// signatures from the resulting remote.Image instead.
+func (i *Image) Signatures() ([]imgutil.Signature, error) {
+	return nil, errSigningRequiresPush
+}
@@ -0,0 +1,25 @@
+package remote
+
+import (
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+
+	"github.com/buildpacks/imgutil"
+)
+
+type imageOptions struct {
+	baseImageRepoName string
+	baseImage         v1.Image
+	createdAt         imgutil.CreatedAt
+	registryConfig    *RegistryConfig
+}
+
+// ImageOption is a functional option for NewImage.
+type ImageOption func(*imageOptions) error
+
+// FromBaseImage seeds the new image by pulling ref from a registry.
+func FromBaseImage(ref string) ImageOption {
+	return func(o *imageOptions) error {
+		o.baseImageRepoName = ref
+		return nil
+	}
+}
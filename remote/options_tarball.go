@@ -0,0 +1,49 @@
+package remote
+
+import (
+	"fmt"
+
+	ggcrlayout "github.com/google/go-containerregistry/pkg/v1/layout"
+	"github.com/google/go-containerregistry/pkg/v1/tarball"
+	"github.com/pkg/errors"
+)
+
+// FromBaseImageTarball seeds the new image from a `docker save`-style
+// tarball at path, instead of pulling a base image from a registry. This
+// lets air-gapped or CI pipelines seed reproducible builds from a vendored
+// artifact.
+func FromBaseImageTarball(path string) ImageOption {
+	return func(o *imageOptions) error {
+		img, err := tarball.ImageFromPath(path, nil)
+		if err != nil {
+			return errors.Wrapf(err, "reading base image tarball %s", path)
+		}
+		o.baseImage = img
+		return nil
+	}
+}
+
+// FromBaseImageLayout seeds the new image from the single-manifest OCI
+// Image Layout directory at path, instead of pulling a base image from a
+// registry.
+func FromBaseImageLayout(path string) ImageOption {
+	return func(o *imageOptions) error {
+		ii, err := ggcrlayout.ImageIndexFromPath(path)
+		if err != nil {
+			return errors.Wrapf(err, "reading base image layout %s", path)
+		}
+		idxManifest, err := ii.IndexManifest()
+		if err != nil {
+			return errors.Wrapf(err, "reading index manifest from %s", path)
+		}
+		if len(idxManifest.Manifests) != 1 {
+			return fmt.Errorf("expected exactly one manifest in %s, found %d", path, len(idxManifest.Manifests))
+		}
+		img, err := ii.Image(idxManifest.Manifests[0].Digest)
+		if err != nil {
+			return errors.Wrapf(err, "reading base image from %s", path)
+		}
+		o.baseImage = img
+		return nil
+	}
+}
@@ -0,0 +1,52 @@
+package remote
+
+import (
+	"testing"
+
+	"github.com/google/go-containerregistry/pkg/name"
+)
+
+func TestRegistryConfigDockerHubNormalization(t *testing.T) {
+	cfg := NewRegistryConfig().
+		WithKeychain("docker.io", staticKeychain{registry: "index.docker.io"}).
+		WithMirror(MirrorRule{SourcePrefix: "docker.io/library/", MirrorPrefix: "mirror.internal/library/"})
+
+	ref, err := name.ParseReference("docker.io/library/busybox:latest", name.WeakValidation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	if got := cfg.keychainFor(ref.Context().RegistryStr(), nil); got == nil {
+		t.Errorf("keychainFor(%s) = nil, want the registered keychain", ref.Context().RegistryStr())
+	}
+
+	rewritten, err := cfg.rewriteForRead(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	want := "mirror.internal/library/busybox:latest"
+	if rewritten.Name() != want {
+		t.Errorf("rewriteForRead(%s) = %s, want %s", ref.Name(), rewritten.Name(), want)
+	}
+}
+
+func TestRegistryConfigDigestMirror(t *testing.T) {
+	cfg := NewRegistryConfig().WithMirror(MirrorRule{SourcePrefix: "docker.io/library/", MirrorPrefix: "mirror.internal/library/"})
+
+	ref, err := name.ParseReference("docker.io/library/busybox@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae", name.WeakValidation)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	rewritten, err := cfg.rewriteForRead(ref)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := rewritten.(name.Digest); !ok {
+		t.Errorf("rewriteForRead(%s) = %T, want a name.Digest", ref.Name(), rewritten)
+	}
+	want := "mirror.internal/library/busybox@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae"
+	if rewritten.Name() != want {
+		t.Errorf("rewriteForRead(%s) = %s, want %s", ref.Name(), rewritten.Name(), want)
+	}
+}
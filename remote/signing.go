@@ -0,0 +1,166 @@
+package remote
+
+import (
+	"encoding/base64"
+	"fmt"
+	"io/ioutil"
+	"strings"
+
+	"github.com/google/go-containerregistry/pkg/name"
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+	"github.com/google/go-containerregistry/pkg/v1/empty"
+	"github.com/google/go-containerregistry/pkg/v1/mutate"
+	ggcrremote "github.com/google/go-containerregistry/pkg/v1/remote"
+	"github.com/google/go-containerregistry/pkg/v1/static"
+	"github.com/google/go-containerregistry/pkg/v1/types"
+	"github.com/pkg/errors"
+
+	"github.com/buildpacks/imgutil"
+)
+
+const (
+	simpleSigningMediaType types.MediaType = "application/vnd.dev.cosign.simplesigning.v1+json"
+	inTotoMediaType        types.MediaType = "application/vnd.in-toto+json"
+
+	signatureAnnotation     = "dev.cosignproject.cosign/signature"
+	certificateAnnotation   = "dev.sigstore.cosign/certificate"
+	chainAnnotation         = "dev.sigstore.cosign/chain"
+	predicateTypeAnnotation = "predicateType"
+)
+
+// AttachSignature attaches payload and its signature as a sibling
+// `sha256-<digest>.sig` image in this image's repository.
+func (i *Image) AttachSignature(payload []byte, signature []byte, cert []byte, chain []byte) error {
+	annotations := map[string]string{
+		signatureAnnotation: base64.StdEncoding.EncodeToString(signature),
+	}
+	if len(cert) > 0 {
+		annotations[certificateAnnotation] = string(cert)
+	}
+	if len(chain) > 0 {
+		annotations[chainAnnotation] = string(chain)
+	}
+	return i.attach(".sig", payload, simpleSigningMediaType, annotations)
+}
+
+// AttachAttestation attaches an in-toto attestation over predicate as a
+// sibling `sha256-<digest>.att` image in this image's repository.
+func (i *Image) AttachAttestation(predicateType string, predicate []byte) error {
+	return i.attach(".att", predicate, inTotoMediaType, map[string]string{
+		predicateTypeAnnotation: predicateType,
+	})
+}
+
+func (i *Image) attach(suffix string, payload []byte, mediaType types.MediaType, annotations map[string]string) error {
+	digest, err := i.image.Digest()
+	if err != nil {
+		return errors.Wrap(err, "reading image digest")
+	}
+
+	ref, err := i.signatureReference(digest, suffix)
+	if err != nil {
+		return err
+	}
+
+	layer := static.NewLayer(payload, mediaType)
+	img, err := mutate.Append(empty.Image, mutate.Addendum{
+		Layer:       layer,
+		Annotations: annotations,
+	})
+	if err != nil {
+		return errors.Wrap(err, "building signature image")
+	}
+
+	opts, err := i.remoteOptions(ref.Context().RegistryStr())
+	if err != nil {
+		return err
+	}
+	return ggcrremote.Write(ref, img, opts...)
+}
+
+// Signatures returns every signature and attestation attached to this image.
+func (i *Image) Signatures() ([]imgutil.Signature, error) {
+	digest, err := i.image.Digest()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading image digest")
+	}
+
+	var sigs []imgutil.Signature
+	for _, suffix := range []string{".sig", ".att"} {
+		ref, err := i.signatureReference(digest, suffix)
+		if err != nil {
+			return nil, err
+		}
+
+		opts, err := i.remoteOptions(ref.Context().RegistryStr())
+		if err != nil {
+			return nil, err
+		}
+		img, err := ggcrremote.Image(ref, opts...)
+		if err != nil {
+			continue // no sibling artifact of this kind has been attached
+		}
+
+		found, err := signaturesFromImage(img, suffix == ".att")
+		if err != nil {
+			return nil, err
+		}
+		sigs = append(sigs, found...)
+	}
+	return sigs, nil
+}
+
+func signaturesFromImage(img v1.Image, attestation bool) ([]imgutil.Signature, error) {
+	layers, err := img.Layers()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading signature image layers")
+	}
+
+	manifest, err := img.Manifest()
+	if err != nil {
+		return nil, errors.Wrap(err, "reading signature image manifest")
+	}
+
+	var sigs []imgutil.Signature
+	for idx, layer := range layers {
+		rc, err := layer.Uncompressed()
+		if err != nil {
+			return nil, errors.Wrap(err, "reading signature layer")
+		}
+		payload, err := ioutil.ReadAll(rc)
+		rc.Close()
+		if err != nil {
+			return nil, errors.Wrap(err, "reading signature payload")
+		}
+
+		annotations := manifest.Layers[idx].Annotations
+		sig := imgutil.Signature{Payload: payload}
+		if attestation {
+			sig.PredicateType = annotations[predicateTypeAnnotation]
+		} else {
+			if encoded, ok := annotations[signatureAnnotation]; ok {
+				decoded, err := base64.StdEncoding.DecodeString(encoded)
+				if err != nil {
+					return nil, errors.Wrap(err, "decoding signature")
+				}
+				sig.Sig = decoded
+			}
+			sig.Cert = []byte(annotations[certificateAnnotation])
+			sig.Chain = []byte(annotations[chainAnnotation])
+		}
+		sigs = append(sigs, sig)
+	}
+	return sigs, nil
+}
+
+// signatureReference derives the sibling reference that cosign itself would
+// use for digest: the repository of this image, tagged
+// `sha256-<hex>` + suffix.
+func (i *Image) signatureReference(digest v1.Hash, suffix string) (name.Reference, error) {
+	ref, err := name.ParseReference(i.repoName, name.WeakValidation)
+	if err != nil {
+		return nil, errors.Wrapf(err, "parsing reference %s", i.repoName)
+	}
+	tag := fmt.Sprintf("%s-%s%s", strings.ToLower(digest.Algorithm), digest.Hex, suffix)
+	return ref.Context().Tag(tag), nil
+}
@@ -0,0 +1,44 @@
+package remote
+
+import (
+	"testing"
+
+	v1 "github.com/google/go-containerregistry/pkg/v1"
+)
+
+func TestSignatureReference(t *testing.T) {
+	digest := v1.Hash{Algorithm: "sha256", Hex: "abc123"}
+
+	for _, tc := range []struct {
+		name     string
+		repoName string
+		want     string
+	}{
+		{
+			name:     "untagged reference",
+			repoName: "gcr.io/org/app",
+			want:     "gcr.io/org/app:sha256-abc123.sig",
+		},
+		{
+			name:     "tagged reference",
+			repoName: "gcr.io/org/app:v1.2.3",
+			want:     "gcr.io/org/app:sha256-abc123.sig",
+		},
+		{
+			name:     "digest reference",
+			repoName: "gcr.io/org/app@sha256:2c26b46b68ffc68ff99b453c1d30413413422d706483bfa0f98a5e886266e7ae",
+			want:     "gcr.io/org/app:sha256-abc123.sig",
+		},
+	} {
+		t.Run(tc.name, func(t *testing.T) {
+			i := &Image{repoName: tc.repoName}
+			ref, err := i.signatureReference(digest, ".sig")
+			if err != nil {
+				t.Fatalf("signatureReference returned error: %v", err)
+			}
+			if ref.String() != tc.want {
+				t.Errorf("signatureReference(%q) = %q, want %q", tc.repoName, ref.String(), tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,33 @@
+package imgutil
+
+// SignedImage extends Image with the ability to attach and enumerate
+// sibling signature and attestation artifacts, following the sigstore/cosign
+// convention of storing them as separate images named sha256-<digest>.sig /
+// sha256-<digest>.att in the same repository as the image itself.
+type SignedImage interface {
+	Image
+
+	// AttachSignature attaches a raw signature over payload, together with
+	// its optional signing certificate and chain (both PEM-encoded), as a
+	// sibling artifact.
+	AttachSignature(payload []byte, signature []byte, cert []byte, chain []byte) error
+
+	// AttachAttestation attaches an in-toto attestation over predicate, of
+	// the given predicateType, as a sibling artifact.
+	AttachAttestation(predicateType string, predicate []byte) error
+
+	// Signatures returns every signature and attestation currently attached
+	// to this image.
+	Signatures() ([]Signature, error)
+}
+
+// Signature is a single attached signature or attestation artifact.
+type Signature struct {
+	// PredicateType is empty for a plain signature, and the in-toto
+	// predicate type for an attestation.
+	PredicateType string
+	Payload       []byte
+	Sig           []byte
+	Cert          []byte
+	Chain         []byte
+}
@@ -0,0 +1,49 @@
+package imgutil
+
+import (
+	"archive/tar"
+	"io"
+	"time"
+
+	"github.com/pkg/errors"
+)
+
+// NormalizeLayerTimestamps copies every entry from r to w, rewriting each
+// header's ModTime, AccessTime, and ChangeTime (and clearing any mtime/atime
+// PAX records, which otherwise take precedence over the header fields) to t.
+// local.Image and remote.Image call this on every layer passed to AddLayer
+// so that a layer's recorded timestamps are governed by the image's
+// CreatedAt policy rather than by the filesystem the build happened to run
+// on.
+func NormalizeLayerTimestamps(w io.Writer, r io.Reader, t time.Time) error {
+	tr := tar.NewReader(r)
+	tw := tar.NewWriter(w)
+
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		if err != nil {
+			return errors.Wrap(err, "reading tar header")
+		}
+
+		header.ModTime = t
+		header.AccessTime = t
+		header.ChangeTime = t
+		delete(header.PAXRecords, "mtime")
+		delete(header.PAXRecords, "atime")
+		delete(header.PAXRecords, "ctime")
+
+		if err := tw.WriteHeader(header); err != nil {
+			return errors.Wrap(err, "writing tar header")
+		}
+		if header.Typeflag == tar.TypeReg {
+			if _, err := io.Copy(tw, tr); err != nil {
+				return errors.Wrap(err, "copying tar entry")
+			}
+		}
+	}
+
+	return tw.Close()
+}
@@ -0,0 +1,55 @@
+package imgutil
+
+import (
+	"archive/tar"
+	"bytes"
+	"testing"
+	"time"
+)
+
+func TestNormalizeLayerTimestamps(t *testing.T) {
+	want := time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)
+
+	var src bytes.Buffer
+	tw := tar.NewWriter(&src)
+	header := &tar.Header{
+		Name:       "file.txt",
+		Typeflag:   tar.TypeReg,
+		Size:       int64(len("hello")),
+		ModTime:    time.Now(),
+		Format:     tar.FormatPAX,
+		PAXRecords: map[string]string{"mtime": "123.456"},
+	}
+	if err := tw.WriteHeader(header); err != nil {
+		t.Fatal(err)
+	}
+	if _, err := tw.Write([]byte("hello")); err != nil {
+		t.Fatal(err)
+	}
+	if err := tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+
+	var dst bytes.Buffer
+	if err := NormalizeLayerTimestamps(&dst, &src, want); err != nil {
+		t.Fatal(err)
+	}
+
+	tr := tar.NewReader(&dst)
+	got, err := tr.Next()
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !got.ModTime.Equal(want) {
+		t.Errorf("ModTime = %v, want %v", got.ModTime, want)
+	}
+	if !got.AccessTime.Equal(want) {
+		t.Errorf("AccessTime = %v, want %v", got.AccessTime, want)
+	}
+	if !got.ChangeTime.Equal(want) {
+		t.Errorf("ChangeTime = %v, want %v", got.ChangeTime, want)
+	}
+	if _, ok := got.PAXRecords["mtime"]; ok {
+		t.Error("mtime PAX record should have been removed")
+	}
+}
@@ -0,0 +1,40 @@
+// Package testhelpers provides small assertion helpers and a local Docker
+// registry fixture for imgutil's acceptance tests. It is intentionally not
+// a general-purpose testing library - it exists only to keep the
+// acceptance suite's Docker/registry bookkeeping out of the test bodies
+// themselves.
+package testhelpers
+
+import (
+	"math/rand"
+	"reflect"
+	"testing"
+)
+
+// AssertNil fails the test if err is non-nil.
+func AssertNil(t *testing.T, err error) {
+	t.Helper()
+	if err != nil {
+		t.Fatalf("expected nil error, got: %v", err)
+	}
+}
+
+// AssertEq fails the test if actual and expected are not deeply equal.
+func AssertEq(t *testing.T, actual, expected interface{}) {
+	t.Helper()
+	if !reflect.DeepEqual(actual, expected) {
+		t.Fatalf("expected %+v to equal %+v", actual, expected)
+	}
+}
+
+const randCharset = "abcdefghijklmnopqrstuvwxyz0123456789"
+
+// RandString returns a random lowercase-alphanumeric string of length n,
+// for building test image names and tags that don't collide across runs.
+func RandString(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = randCharset[rand.Intn(len(randCharset))]
+	}
+	return string(b)
+}
@@ -0,0 +1,53 @@
+package testhelpers
+
+import (
+	"context"
+	"io"
+	"io/ioutil"
+	"testing"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/client"
+)
+
+// DockerCli returns a Docker client configured from the environment
+// (DOCKER_HOST, DOCKER_CERT_PATH, etc.), failing the test if the daemon
+// can't be reached.
+func DockerCli(t *testing.T) client.CommonAPIClient {
+	t.Helper()
+	cli, err := client.NewClientWithOpts(client.FromEnv, client.WithAPIVersionNegotiation())
+	AssertNil(t, err)
+	return cli
+}
+
+// PullImage pulls ref into the daemon and returns any error.
+func PullImage(dockerCli client.CommonAPIClient, ref string) error {
+	rc, err := dockerCli.ImagePull(context.Background(), ref, types.ImagePullOptions{})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(ioutil.Discard, rc)
+	return err
+}
+
+// PushImage pushes ref from the daemon to its registry, authenticating
+// with the base64-encoded auth config returned by
+// DockerRegistry.DockerRegistryAuth.
+func PushImage(dockerCli client.CommonAPIClient, ref string, registryAuth string) error {
+	rc, err := dockerCli.ImagePush(context.Background(), ref, types.ImagePushOptions{RegistryAuth: registryAuth})
+	if err != nil {
+		return err
+	}
+	defer rc.Close()
+	_, err = io.Copy(ioutil.Discard, rc)
+	return err
+}
+
+// DockerRmi removes each of refs from the daemon, ignoring errors - it is
+// meant for best-effort test cleanup, where the image may already be gone.
+func DockerRmi(dockerCli client.CommonAPIClient, refs ...string) {
+	for _, ref := range refs {
+		_, _ = dockerCli.ImageRemove(context.Background(), ref, types.ImageRemoveOptions{Force: true})
+	}
+}
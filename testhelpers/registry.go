@@ -0,0 +1,114 @@
+package testhelpers
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/docker/docker/api/types"
+	"github.com/docker/docker/api/types/container"
+	"github.com/docker/docker/client"
+	"github.com/docker/go-connections/nat"
+	"github.com/google/go-containerregistry/pkg/authn"
+)
+
+const registryContainerPort = "5000"
+
+// DockerRegistry is a throwaway, unauthenticated registry:2 container,
+// bound to a random host port, for acceptance tests that need a real
+// registry to push to and pull from.
+type DockerRegistry struct {
+	Host string
+	Port string
+
+	dockerCli   client.CommonAPIClient
+	containerID string
+}
+
+// NewDockerRegistry returns a DockerRegistry. Call Start before using it.
+func NewDockerRegistry() *DockerRegistry {
+	return &DockerRegistry{Host: "localhost"}
+}
+
+// Start pulls and runs the registry:2 image, and blocks until the container
+// can be inspected plus a short grace period, failing the test on error.
+func (r *DockerRegistry) Start(t *testing.T) {
+	t.Helper()
+	r.dockerCli = DockerCli(t)
+	ctx := context.Background()
+
+	AssertNil(t, PullImage(r.dockerCli, "registry:2"))
+
+	exposedPorts, portBindings, err := nat.ParsePortSpecs([]string{registryContainerPort})
+	AssertNil(t, err)
+
+	created, err := r.dockerCli.ContainerCreate(ctx, &container.Config{
+		Image:        "registry:2",
+		ExposedPorts: exposedPorts,
+	}, &container.HostConfig{
+		PortBindings: portBindings,
+	}, nil, nil, "")
+	AssertNil(t, err)
+	r.containerID = created.ID
+
+	AssertNil(t, r.dockerCli.ContainerStart(ctx, r.containerID, types.ContainerStartOptions{}))
+
+	info, err := r.dockerCli.ContainerInspect(ctx, r.containerID)
+	AssertNil(t, err)
+	bindings := info.NetworkSettings.Ports[nat.Port(registryContainerPort+"/tcp")]
+	if len(bindings) == 0 {
+		t.Fatalf("registry container %s has no bound port", r.containerID)
+	}
+	r.Port = bindings[0].HostPort
+
+	r.waitForReady(t)
+}
+
+func (r *DockerRegistry) waitForReady(t *testing.T) {
+	t.Helper()
+	deadline := time.Now().Add(30 * time.Second)
+	var lastErr error
+	for time.Now().Before(deadline) {
+		if _, err := r.dockerCli.ContainerInspect(context.Background(), r.containerID); err == nil {
+			// The registry API itself doesn't have a dedicated health-check
+			// endpoint wired up here; a successful container inspect plus a
+			// short grace period is enough for registry:2's fast startup.
+			time.Sleep(250 * time.Millisecond)
+			return
+		} else {
+			lastErr = err
+		}
+	}
+	t.Fatalf("timed out waiting for registry container to be ready: %v", lastErr)
+}
+
+// Stop removes the registry container. It is a no-op if Start was never
+// called (or never got far enough to create the container), so it's safe
+// to defer unconditionally after NewDockerRegistry.
+func (r *DockerRegistry) Stop(t *testing.T) {
+	t.Helper()
+	if r == nil || r.containerID == "" {
+		return
+	}
+	AssertNil(t, r.dockerCli.ContainerRemove(context.Background(), r.containerID, types.ContainerRemoveOptions{Force: true}))
+}
+
+// anonymousKeychain resolves to authn.Anonymous for every registry.
+type anonymousKeychain struct{}
+
+func (anonymousKeychain) Resolve(authn.Resource) (authn.Authenticator, error) {
+	return authn.Anonymous, nil
+}
+
+// GGCRKeychain returns the go-containerregistry keychain for this
+// registry - anonymous, since registry:2 runs without auth configured.
+func (r *DockerRegistry) GGCRKeychain() authn.Keychain {
+	return anonymousKeychain{}
+}
+
+// DockerRegistryAuth returns the base64-encoded auth config to pass as
+// types.ImagePushOptions.RegistryAuth when pushing to this registry - empty,
+// since registry:2 runs without auth configured.
+func (r *DockerRegistry) DockerRegistryAuth() string {
+	return ""
+}
@@ -0,0 +1,34 @@
+package testhelpers
+
+import (
+	"archive/tar"
+	"bytes"
+	"io"
+)
+
+// CreateSingleFileTar returns an uncompressed tar containing a single
+// regular file at path with the given contents, suitable for
+// imgutil.Image.AddLayer. osType is accepted for parity with callers that
+// branch on the daemon's OS (Windows layers require additional Hyperv/base
+// layer metadata that this helper does not attempt to produce), but is
+// otherwise unused - Linux-style tar entries work for the Linux and Windows
+// process-isolated containers imgutil's acceptance suite targets.
+func CreateSingleFileTar(path, contents, osType string) (io.Reader, error) {
+	var buf bytes.Buffer
+	tw := tar.NewWriter(&buf)
+
+	if err := tw.WriteHeader(&tar.Header{
+		Name: path,
+		Size: int64(len(contents)),
+		Mode: 0644,
+	}); err != nil {
+		return nil, err
+	}
+	if _, err := tw.Write([]byte(contents)); err != nil {
+		return nil, err
+	}
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	return &buf, nil
+}